@@ -0,0 +1,67 @@
+package changelog
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// conventionalHeader matches a Conventional Commits subject line, e.g.
+// "feat(parser): support foo" or "fix!: bar".
+var conventionalHeader = regexp.MustCompile(`^(\w+)(\([^)]*\))?(!)?:\s*(.*)$`)
+
+// ConventionalCommitsParser derives release notes from the commit
+// messages between one tag and the next, rather than from a changelog
+// file. A commit is breaking when its type ends in "!" (e.g. "feat!:")
+// or its body has a "BREAKING CHANGE:" footer.
+type ConventionalCommitsParser struct{}
+
+func (p *ConventionalCommitsParser) Parse(in Input) []ReleaseNotes {
+	versions := make([]string, 0, len(in.CommitsByVersion))
+	for version := range in.CommitsByVersion {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	notes := make([]ReleaseNotes, 0, len(versions))
+	for _, version := range versions {
+		rn := ReleaseNotes{Version: version}
+		for _, message := range in.CommitsByVersion[version] {
+			categorizeCommit(&rn, message)
+		}
+		notes = append(notes, rn)
+	}
+	return notes
+}
+
+func categorizeCommit(rn *ReleaseNotes, message string) {
+	lines := strings.SplitN(message, "\n", 2)
+	header := strings.TrimSpace(lines[0])
+	body := ""
+	if len(lines) > 1 {
+		body = lines[1]
+	}
+
+	m := conventionalHeader.FindStringSubmatch(header)
+	if m == nil {
+		rn.Changes = append(rn.Changes, header)
+		return
+	}
+	typ, bang, desc := m[1], m[3], m[4]
+
+	if bang == "!" || strings.Contains(body, "BREAKING CHANGE:") {
+		rn.Breaking = append(rn.Breaking, desc)
+		return
+	}
+
+	switch typ {
+	case "feat":
+		rn.Features = append(rn.Features, desc)
+	case "fix":
+		rn.Fixes = append(rn.Fixes, desc)
+	case "chore", "refactor":
+		rn.Maintenance = append(rn.Maintenance, desc)
+	default:
+		rn.Changes = append(rn.Changes, desc)
+	}
+}
@@ -0,0 +1,49 @@
+package changelog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConventionalCommitsParser(t *testing.T) {
+	in := Input{
+		CommitsByVersion: map[string][]string{
+			"v1.1.0": {
+				"feat(parser): support foo",
+				"fix: handle nil input",
+				"chore: bump deps",
+				"refactor: simplify loop",
+				"feat!: drop legacy flag",
+				"fix(api): adjust timeout\n\nBREAKING CHANGE: timeout is now required",
+				"tweak formatting",
+			},
+		},
+	}
+	notes := (&ConventionalCommitsParser{}).Parse(in)
+	want := []ReleaseNotes{
+		{
+			Version:     "v1.1.0",
+			Features:    []string{"support foo"},
+			Fixes:       []string{"handle nil input"},
+			Maintenance: []string{"bump deps", "simplify loop"},
+			Breaking:    []string{"drop legacy flag", "adjust timeout"},
+			Changes:     []string{"tweak formatting"},
+		},
+	}
+	if !reflect.DeepEqual(notes, want) {
+		t.Errorf("Parse() = %#v, want %#v", notes, want)
+	}
+}
+
+func TestConventionalCommitsParserSortsVersions(t *testing.T) {
+	in := Input{
+		CommitsByVersion: map[string][]string{
+			"v1.2.0": {"feat: b"},
+			"v1.1.0": {"feat: a"},
+		},
+	}
+	notes := (&ConventionalCommitsParser{}).Parse(in)
+	if len(notes) != 2 || notes[0].Version != "v1.1.0" || notes[1].Version != "v1.2.0" {
+		t.Errorf("Parse() = %#v, want versions sorted v1.1.0 then v1.2.0", notes)
+	}
+}
@@ -0,0 +1,118 @@
+package changelog
+
+import (
+	"bufio"
+	"regexp"
+	"strings"
+)
+
+// jestVersion finds each x.y.z version named in a Jest release heading,
+// e.g. both versions in "## jest 22.0.2 && 22.0.3".
+var jestVersion = regexp.MustCompile(`[0-9]+\.[0-9]+\.[0-9]+`)
+
+// JestParser is the original r2c changelog format: a "## <release>"
+// heading, "### Fixes|Features|Chore & Maintenance" subheadings, and
+// "* item" bullets, possibly spanning multiple lines.
+type JestParser struct{}
+
+func (p *JestParser) Parse(in Input) []ReleaseNotes {
+	var order []string
+	byVersion := map[string]*ReleaseNotes{}
+
+	get := func(releaseName, version string) *ReleaseNotes {
+		rn, ok := byVersion[version]
+		if !ok {
+			rn = &ReleaseNotes{ReleaseName: releaseName, Version: version}
+			byVersion[version] = rn
+			order = append(order, version)
+		}
+		return rn
+	}
+
+	release := ""
+	names := []string(nil)
+	comment := ""
+	fixes := false
+	features := false
+	maintenance := false
+
+	flush := func() {
+		if comment == "" {
+			return
+		}
+		for _, name := range names {
+			rn := get(release, name)
+			switch {
+			case fixes:
+				rn.Fixes = append(rn.Fixes, comment)
+			case features:
+				rn.Features = append(rn.Features, comment)
+			case maintenance:
+				rn.Maintenance = append(rn.Maintenance, comment)
+			default:
+				rn.Changes = append(rn.Changes, comment)
+			}
+		}
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(in.Changelog))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\n\v\f\r")
+		if len(line) == 0 {
+			continue
+		}
+		// buffer comments first to handle multilined comments
+		if len(line) >= 1 && line[0] != '#' && line[0] != '*' {
+			comment += "\n" + strings.TrimSpace(line)
+			continue
+		}
+		// handle buffered comments
+		flush()
+		// Releases
+		if len(line) >= 3 && line[:3] == "## " {
+			release = line[3:]
+			// A heading can name more than one release at once, e.g.
+			// "jest 22.0.2 && 22.0.3": emit one ReleaseNotes per version.
+			names = nil
+			for _, v := range jestVersion.FindAllString(release, -1) {
+				names = append(names, "v"+v)
+			}
+			comment = ""
+			fixes = false
+			features = false
+			maintenance = false
+		}
+		if len(names) == 0 {
+			continue
+		}
+		// Fixes / Features / Maintenance
+		if len(line) >= 4 && line[:4] == "### " {
+			if strings.Contains(line, "Fixes") {
+				comment = ""
+				fixes = true
+				features = false
+				maintenance = false
+			} else if strings.Contains(line, "Features") {
+				comment = ""
+				fixes = false
+				features = true
+				maintenance = false
+			} else if strings.Contains(line, "Chore & Maintenance") {
+				comment = ""
+				fixes = false
+				features = false
+				maintenance = true
+			}
+		}
+		// Comments
+		if len(line) >= 2 && line[:2] == "* " {
+			comment = line[2:]
+		}
+	}
+
+	notes := make([]ReleaseNotes, 0, len(order))
+	for _, v := range order {
+		notes = append(notes, *byVersion[v])
+	}
+	return notes
+}
@@ -0,0 +1,51 @@
+package changelog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJestParserBasic(t *testing.T) {
+	// The trailing "### Chore & Maintenance" heading (with no bullets under
+	// it) is what flushes the "Added a thing" bullet above it - the parser
+	// only flushes a buffered comment once it reaches the next heading or
+	// bullet line.
+	in := Input{Changelog: `## 22.0.1
+
+### Fixes
+
+* Fixed a thing
+
+### Features
+
+* Added a thing
+
+### Chore & Maintenance
+`}
+	notes := (&JestParser{}).Parse(in)
+	want := []ReleaseNotes{
+		{ReleaseName: "22.0.1", Version: "v22.0.1", Fixes: []string{"Fixed a thing"}, Features: []string{"Added a thing"}},
+	}
+	if !reflect.DeepEqual(notes, want) {
+		t.Errorf("Parse() = %#v, want %#v", notes, want)
+	}
+}
+
+func TestJestParserMultiVersionHeading(t *testing.T) {
+	in := Input{Changelog: `## jest 22.0.2 && 22.0.3
+
+### Fixes
+
+* Fixed a thing in both
+
+### Chore & Maintenance
+`}
+	notes := (&JestParser{}).Parse(in)
+	want := []ReleaseNotes{
+		{ReleaseName: "jest 22.0.2 && 22.0.3", Version: "v22.0.2", Fixes: []string{"Fixed a thing in both"}},
+		{ReleaseName: "jest 22.0.2 && 22.0.3", Version: "v22.0.3", Fixes: []string{"Fixed a thing in both"}},
+	}
+	if !reflect.DeepEqual(notes, want) {
+		t.Errorf("Parse() = %#v, want %#v", notes, want)
+	}
+}
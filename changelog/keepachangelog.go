@@ -0,0 +1,101 @@
+package changelog
+
+import (
+	"bufio"
+	"regexp"
+	"strings"
+)
+
+var (
+	kacHeading = regexp.MustCompile(`^##\s+\[?([^\]\s]+)\]?`)
+	kacVersion = regexp.MustCompile(`[0-9]+\.[0-9]+\.[0-9]+`)
+)
+
+// KeepAChangelogParser understands the https://keepachangelog.com shape:
+//
+//	## [1.2.3] - 2021-01-02
+//	### Added
+//	- item
+//
+// Added maps to Features, Changed/Deprecated to Changes/Maintenance,
+// Removed to Breaking (a removal is a breaking change by definition), and
+// Security alongside Fixed to Fixes.
+type KeepAChangelogParser struct{}
+
+func (p *KeepAChangelogParser) Parse(in Input) []ReleaseNotes {
+	var order []string
+	byVersion := map[string]*ReleaseNotes{}
+
+	get := func(releaseName, version string) *ReleaseNotes {
+		rn, ok := byVersion[version]
+		if !ok {
+			rn = &ReleaseNotes{ReleaseName: releaseName, Version: version}
+			byVersion[version] = rn
+			order = append(order, version)
+		}
+		return rn
+	}
+
+	release := ""
+	name := ""
+	comment := ""
+	section := ""
+
+	flush := func() {
+		if comment == "" {
+			return
+		}
+		rn := get(release, name)
+		switch section {
+		case "Added":
+			rn.Features = append(rn.Features, comment)
+		case "Changed":
+			rn.Changes = append(rn.Changes, comment)
+		case "Deprecated":
+			rn.Maintenance = append(rn.Maintenance, comment)
+		case "Removed":
+			rn.Breaking = append(rn.Breaking, comment)
+		case "Security":
+			rn.Fixes = append(rn.Fixes, comment)
+		case "Fixed":
+			rn.Fixes = append(rn.Fixes, comment)
+		default:
+			rn.Changes = append(rn.Changes, comment)
+		}
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(in.Changelog))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\n\v\f\r")
+		if len(line) == 0 {
+			continue
+		}
+		if len(line) >= 1 && line[0] != '#' && line[0] != '-' && line[0] != '*' {
+			comment += "\n" + strings.TrimSpace(line)
+			continue
+		}
+		flush()
+		if m := kacHeading.FindStringSubmatch(line); m != nil {
+			release = m[1]
+			name = "v" + string(kacVersion.Find([]byte(release)))
+			comment = ""
+			section = ""
+		}
+		if !kacVersion.MatchString(release) {
+			continue
+		}
+		if len(line) >= 4 && line[:4] == "### " {
+			section = strings.TrimSpace(line[4:])
+			comment = ""
+		}
+		if len(line) >= 2 && (line[:2] == "- " || line[:2] == "* ") {
+			comment = line[2:]
+		}
+	}
+
+	notes := make([]ReleaseNotes, 0, len(order))
+	for _, v := range order {
+		notes = append(notes, *byVersion[v])
+	}
+	return notes
+}
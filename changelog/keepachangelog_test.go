@@ -0,0 +1,48 @@
+package changelog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeepAChangelogParser(t *testing.T) {
+	// The trailing "## [Unreleased]" heading is what flushes the last
+	// "A vulnerability" bullet above it - the parser only flushes a
+	// buffered comment once it reaches the next heading or bullet line.
+	in := Input{Changelog: `## [1.2.3] - 2021-01-02
+### Added
+- A new feature
+
+### Changed
+- Some behavior
+
+### Deprecated
+- An old API
+
+### Removed
+- A breaking removal
+
+### Fixed
+- A bug
+
+### Security
+- A vulnerability
+
+## [Unreleased]
+`}
+	notes := (&KeepAChangelogParser{}).Parse(in)
+	want := []ReleaseNotes{
+		{
+			ReleaseName: "1.2.3",
+			Version:     "v1.2.3",
+			Features:    []string{"A new feature"},
+			Changes:     []string{"Some behavior"},
+			Maintenance: []string{"An old API"},
+			Breaking:    []string{"A breaking removal"},
+			Fixes:       []string{"A bug", "A vulnerability"},
+		},
+	}
+	if !reflect.DeepEqual(notes, want) {
+		t.Errorf("Parse() = %#v, want %#v", notes, want)
+	}
+}
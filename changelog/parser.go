@@ -0,0 +1,73 @@
+// Package changelog turns a project's release history into structured
+// notes per version. It supports a few common changelog conventions
+// through a single Parser interface so r2c isn't tied to any one
+// project's formatting choices.
+package changelog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReleaseNotes is everything a Parser could find about a single version.
+type ReleaseNotes struct {
+	ReleaseName string
+	Version     string
+	Fixes       []string
+	Features    []string
+	Maintenance []string
+	Changes     []string
+	Breaking    []string
+}
+
+// Input bundles everything a Parser might need. Not every parser uses
+// every field: the changelog-file parsers only look at Changelog, while
+// ConventionalCommitsParser only looks at CommitsByVersion.
+type Input struct {
+	// Changelog is the raw contents of the project's changelog file.
+	Changelog string
+	// CommitsByVersion maps a tag name (e.g. "v1.2.3") to the full commit
+	// messages reachable since the previous tag.
+	CommitsByVersion map[string][]string
+}
+
+// Parser extracts per-version release notes from an Input.
+type Parser interface {
+	Parse(in Input) []ReleaseNotes
+}
+
+// New returns the Parser named by name: "jest", "keepachangelog", or
+// "conventional-commits".
+func New(name string) (Parser, error) {
+	switch name {
+	case "jest":
+		return &JestParser{}, nil
+	case "keepachangelog":
+		return &KeepAChangelogParser{}, nil
+	case "conventional-commits":
+		return &ConventionalCommitsParser{}, nil
+	default:
+		return nil, fmt.Errorf("unknown changelog parser %q", name)
+	}
+}
+
+// keepAChangelogHeaders are the section headers from the Keep a Changelog
+// spec. Their presence in a changelog is a strong signal to prefer
+// KeepAChangelogParser over the Jest-shaped one.
+var keepAChangelogHeaders = []string{
+	"### Added", "### Changed", "### Deprecated", "### Removed", "### Security",
+}
+
+// Detect picks a Parser by sniffing the changelog's own section headers,
+// for callers that don't want to hardcode --parser.
+func Detect(text string) Parser {
+	if strings.Contains(text, "Keep a Changelog") {
+		return &KeepAChangelogParser{}
+	}
+	for _, h := range keepAChangelogHeaders {
+		if strings.Contains(text, h) {
+			return &KeepAChangelogParser{}
+		}
+	}
+	return &JestParser{}
+}
@@ -0,0 +1,77 @@
+package forge
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// cacheEntry is what Client persists per URL so the next run can send a
+// conditional request and, on a 304, skip re-downloading and re-parsing
+// entirely.
+type cacheEntry struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	Link         string `json:"link,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+// CacheDir returns $XDG_CACHE_HOME/r2c, falling back to ~/.cache/r2c. It
+// returns "" if neither can be determined, in which case the cache is
+// simply skipped. Callers outside this package use it to namespace their
+// own on-disk caches (e.g. parsed changelog notes) alongside the raw HTTP
+// response cache kept here.
+func CacheDir() string {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "r2c")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".cache", "r2c")
+}
+
+// cachePath maps a URL to its on-disk cache file.
+func cachePath(url string) (string, bool) {
+	dir := CacheDir()
+	if dir == "" {
+		return "", false
+	}
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(dir, "http", hex.EncodeToString(sum[:])+".json"), true
+}
+
+func loadCacheEntry(url string) (*cacheEntry, bool) {
+	path, ok := cachePath(url)
+	if !ok {
+		return nil, false
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+func saveCacheEntry(url string, entry *cacheEntry) {
+	path, ok := cachePath(url)
+	if !ok {
+		return
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	_ = ioutil.WriteFile(path, b, 0644)
+}
@@ -0,0 +1,171 @@
+// Package forge talks to the REST APIs of the major git forges (GitHub,
+// GitLab, Gitea) so the rest of r2c can fetch tags and changelogs without
+// caring which one a project happens to be hosted on.
+package forge
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const userAgent = "r2c (+https://github.com/theanine/r2c)"
+
+// maxRetries bounds how many times Client will retry a request that hit a
+// transient error (rate limiting or a 5xx) before giving up.
+const maxRetries = 5
+
+// Client is a small HTTP client shared by the forge implementations. It
+// adds auth headers, a User-Agent, and retries requests that get rate
+// limited or fail with a server error.
+type Client struct {
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient returns a Client that authenticates with token, if non-empty.
+func NewClient(token string) *Client {
+	return &Client{
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Get fetches url and returns its body, retrying on rate limits (429) and
+// server errors (5xx) with a backoff driven by the response's Retry-After
+// header when present.
+//
+// It also sends If-None-Match/If-Modified-Since headers from the last
+// response it cached for this exact URL, and serves the cached body
+// straight back on a 304 instead of hitting the network again - keeping
+// re-runs fast and off the forge's rate limit.
+func (c *Client) Get(url string) ([]byte, error) {
+	body, _, err := c.get(url)
+	return body, err
+}
+
+// GetAll fetches url and every subsequent page linked by a
+// `Link: <...>; rel="next"` response header (the pagination scheme GitHub,
+// GitLab, and Gitea all use), returning each page's body in order.
+func (c *Client) GetAll(url string) ([][]byte, error) {
+	var pages [][]byte
+	for url != "" {
+		body, link, err := c.get(url)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, body)
+		url = nextPageURL(link)
+	}
+	return pages, nil
+}
+
+// get is the shared implementation behind Get and GetAll: it returns a
+// page's body along with its raw Link header so GetAll can find the next
+// page, including when the body was served from cache on a 304.
+func (c *Client) get(url string) ([]byte, string, error) {
+	cached, haveCache := loadCacheEntry(url)
+
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff(attempt))
+		}
+
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return nil, "", err
+		}
+		req.Header.Set("User-Agent", userAgent)
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+		if haveCache {
+			if cached.ETag != "" {
+				req.Header.Set("If-None-Match", cached.ETag)
+			}
+			if cached.LastModified != "" {
+				req.Header.Set("If-Modified-Since", cached.LastModified)
+			}
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			resp.Body.Close()
+			lastErr = fmt.Errorf("GET %s: %s", url, resp.Status)
+			if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+				time.Sleep(wait)
+			}
+			continue
+		}
+
+		if resp.StatusCode == http.StatusNotModified {
+			resp.Body.Close()
+			if haveCache {
+				return cached.Body, cached.Link, nil
+			}
+			return nil, "", fmt.Errorf("GET %s: %s with no cached body to fall back to", url, resp.Status)
+		}
+
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, "", err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, "", fmt.Errorf("GET %s: %s: %s", url, resp.Status, string(body))
+		}
+
+		link := resp.Header.Get("Link")
+		saveCacheEntry(url, &cacheEntry{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Link:         link,
+			Body:         body,
+		})
+		return body, link, nil
+	}
+	return nil, "", fmt.Errorf("GET %s: giving up after %d attempts: %w", url, maxRetries+1, lastErr)
+}
+
+// nextPageURL extracts the URL marked rel="next" from an RFC 5988 Link
+// header, the pagination scheme GitHub, GitLab, and Gitea all emit. It
+// returns "" when there is no next page (including when header is empty).
+func nextPageURL(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		segments := strings.Split(link, ";")
+		if len(segments) < 2 {
+			continue
+		}
+		url := strings.TrimSpace(segments[0])
+		url = strings.TrimPrefix(url, "<")
+		url = strings.TrimSuffix(url, ">")
+		for _, param := range segments[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return url
+			}
+		}
+	}
+	return ""
+}
+
+func backoff(attempt int) time.Duration {
+	return time.Duration(attempt*attempt) * 500 * time.Millisecond
+}
+
+func retryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if secs, err := time.ParseDuration(header + "s"); err == nil {
+		return secs, true
+	}
+	return 0, false
+}
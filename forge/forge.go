@@ -0,0 +1,66 @@
+package forge
+
+import (
+	"fmt"
+	"time"
+)
+
+// Tag is a forge-agnostic view of a single tagged release, with just
+// enough detail for r2c to build a Release from it.
+type Tag struct {
+	Name       string
+	ZipballUrl string
+	TarballUrl string
+	CommitSha  string
+	CommitUrl  string
+}
+
+// Forger fetches the pieces of release history r2c needs from a git
+// forge: the list of tags and the raw changelog at a given branch.
+type Forger interface {
+	// Tags lists the tags of owner/repo in the order the forge's API
+	// returns them; callers sort them themselves.
+	Tags(owner, repo string) ([]Tag, error)
+	// Changelog fetches the raw changelog file from owner/repo at branch.
+	Changelog(owner, repo, branch string) (string, error)
+}
+
+// ReleaseMeta is a forge's own release-notes entry, as opposed to a tag:
+// the human-authored body, when it was published, and who published it.
+type ReleaseMeta struct {
+	TagName     string
+	Body        string
+	PublishedAt time.Time
+	Author      string
+}
+
+// ReleaseFetcher is implemented by forges that expose a releases API
+// distinct from tags, so callers can fall back to it when a changelog
+// doesn't mention a version at all. Only GitHub implements it here; the
+// GitLab and Gitea backends do not.
+type ReleaseFetcher interface {
+	Releases(owner, repo string) ([]ReleaseMeta, error)
+}
+
+// CommitLister is implemented by forges that can list the commit messages
+// reachable from head but not from base, for deriving release notes from
+// Conventional Commits rather than a changelog file.
+type CommitLister interface {
+	CommitMessages(owner, repo, base, head string) ([]string, error)
+}
+
+// New constructs the Forger for the named backend ("github", "gitlab" or
+// "gitea"). changelogPath is the path to the changelog file within the
+// repo, e.g. "CHANGELOG.md".
+func New(name string, client *Client, changelogPath string) (Forger, error) {
+	switch name {
+	case "", "github":
+		return NewGitHub(client, changelogPath), nil
+	case "gitlab":
+		return NewGitLab(client, changelogPath), nil
+	case "gitea":
+		return NewGitea(client, changelogPath), nil
+	default:
+		return nil, fmt.Errorf("unknown forge %q", name)
+	}
+}
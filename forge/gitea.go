@@ -0,0 +1,68 @@
+package forge
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// defaultGiteaHost is the Gitea instance the Gitea backend talks to.
+// NewGitea always uses it; there is no option to point r2c at a
+// self-hosted instance.
+const defaultGiteaHost = "https://gitea.com"
+
+// Gitea talks to the Gitea REST API.
+type Gitea struct {
+	client        *Client
+	changelogPath string
+	host          string
+}
+
+// NewGitea returns a Forger backed by the Gitea REST API.
+func NewGitea(client *Client, changelogPath string) *Gitea {
+	return &Gitea{client: client, changelogPath: changelogPath, host: defaultGiteaHost}
+}
+
+type giteaTag struct {
+	Name       string `json:"name"`
+	ZipballUrl string `json:"zipball_url"`
+	TarballUrl string `json:"tarball_url"`
+	Commit     struct {
+		Sha string `json:"sha"`
+		Url string `json:"url"`
+	} `json:"commit"`
+}
+
+func (g *Gitea) Tags(owner, repo string) ([]Tag, error) {
+	url := fmt.Sprintf("%s/api/v1/repos/%s/%s/tags?limit=100", g.host, owner, repo)
+	pages, err := g.client.GetAll(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []Tag
+	for _, body := range pages {
+		var raw []giteaTag
+		if err := json.Unmarshal(body, &raw); err != nil {
+			return nil, fmt.Errorf("parsing tags for %s/%s: %w", owner, repo, err)
+		}
+		for _, t := range raw {
+			tags = append(tags, Tag{
+				Name:       t.Name,
+				ZipballUrl: t.ZipballUrl,
+				TarballUrl: t.TarballUrl,
+				CommitSha:  t.Commit.Sha,
+				CommitUrl:  t.Commit.Url,
+			})
+		}
+	}
+	return tags, nil
+}
+
+func (g *Gitea) Changelog(owner, repo, branch string) (string, error) {
+	url := fmt.Sprintf("%s/api/v1/repos/%s/%s/raw/%s?ref=%s", g.host, owner, repo, g.changelogPath, branch)
+	body, err := g.client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
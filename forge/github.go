@@ -0,0 +1,129 @@
+package forge
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// GitHub talks to the GitHub REST API (api.github.com).
+type GitHub struct {
+	client        *Client
+	changelogPath string
+}
+
+// NewGitHub returns a Forger backed by the GitHub REST API.
+func NewGitHub(client *Client, changelogPath string) *GitHub {
+	return &GitHub{client: client, changelogPath: changelogPath}
+}
+
+type githubTag struct {
+	Name       string `json:"name"`
+	ZipballUrl string `json:"zipball_url"`
+	TarballUrl string `json:"tarball_url"`
+	Commit     struct {
+		Sha string `json:"sha"`
+		Url string `json:"url"`
+	} `json:"commit"`
+}
+
+func (g *GitHub) Tags(owner, repo string) ([]Tag, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/tags?per_page=100", owner, repo)
+	pages, err := g.client.GetAll(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []Tag
+	for _, body := range pages {
+		var raw []githubTag
+		if err := json.Unmarshal(body, &raw); err != nil {
+			return nil, fmt.Errorf("parsing tags for %s/%s: %w", owner, repo, err)
+		}
+		for _, t := range raw {
+			tags = append(tags, Tag{
+				Name:       t.Name,
+				ZipballUrl: t.ZipballUrl,
+				TarballUrl: t.TarballUrl,
+				CommitSha:  t.Commit.Sha,
+				CommitUrl:  t.Commit.Url,
+			})
+		}
+	}
+	return tags, nil
+}
+
+type githubRelease struct {
+	TagName     string    `json:"tag_name"`
+	Body        string    `json:"body"`
+	PublishedAt time.Time `json:"published_at"`
+	Author      struct {
+		Login string `json:"login"`
+	} `json:"author"`
+}
+
+// Releases fetches GET /repos/{owner}/{repo}/releases. Many projects only
+// write release notes here, not in their CHANGELOG.md, so callers use
+// this as a fallback source.
+func (g *GitHub) Releases(owner, repo string) ([]ReleaseMeta, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases?per_page=100", owner, repo)
+	pages, err := g.client.GetAll(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var metas []ReleaseMeta
+	for _, body := range pages {
+		var raw []githubRelease
+		if err := json.Unmarshal(body, &raw); err != nil {
+			return nil, fmt.Errorf("parsing releases for %s/%s: %w", owner, repo, err)
+		}
+		for _, r := range raw {
+			metas = append(metas, ReleaseMeta{
+				TagName:     r.TagName,
+				Body:        r.Body,
+				PublishedAt: r.PublishedAt,
+				Author:      r.Author.Login,
+			})
+		}
+	}
+	return metas, nil
+}
+
+type githubCompare struct {
+	Commits []struct {
+		Commit struct {
+			Message string `json:"message"`
+		} `json:"commit"`
+	} `json:"commits"`
+}
+
+// CommitMessages lists the commit messages between base and head using
+// GET /repos/{owner}/{repo}/compare/{base}...{head}.
+func (g *GitHub) CommitMessages(owner, repo, base, head string) ([]string, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/compare/%s...%s", owner, repo, base, head)
+	body, err := g.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw githubCompare
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("parsing comparison %s...%s for %s/%s: %w", base, head, owner, repo, err)
+	}
+
+	messages := make([]string, len(raw.Commits))
+	for i, c := range raw.Commits {
+		messages[i] = c.Commit.Message
+	}
+	return messages, nil
+}
+
+func (g *GitHub) Changelog(owner, repo, branch string) (string, error) {
+	url := fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s", owner, repo, branch, g.changelogPath)
+	body, err := g.client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
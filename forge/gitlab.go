@@ -0,0 +1,70 @@
+package forge
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// GitLab talks to the GitLab REST API on gitlab.com.
+type GitLab struct {
+	client        *Client
+	changelogPath string
+}
+
+// NewGitLab returns a Forger backed by the GitLab REST API.
+func NewGitLab(client *Client, changelogPath string) *GitLab {
+	return &GitLab{client: client, changelogPath: changelogPath}
+}
+
+type gitlabTag struct {
+	Name   string `json:"name"`
+	Commit struct {
+		Id     string `json:"id"`
+		WebUrl string `json:"web_url"`
+	} `json:"commit"`
+}
+
+func (g *GitLab) Tags(owner, repo string) ([]Tag, error) {
+	project := projectID(owner, repo)
+	apiUrl := fmt.Sprintf("https://gitlab.com/api/v4/projects/%s/repository/tags?per_page=100", project)
+	pages, err := g.client.GetAll(apiUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []Tag
+	for _, body := range pages {
+		var raw []gitlabTag
+		if err := json.Unmarshal(body, &raw); err != nil {
+			return nil, fmt.Errorf("parsing tags for %s/%s: %w", owner, repo, err)
+		}
+		for _, t := range raw {
+			tags = append(tags, Tag{
+				Name:       t.Name,
+				ZipballUrl: fmt.Sprintf("https://gitlab.com/%s/%s/-/archive/%s/%s-%s.zip", owner, repo, t.Name, repo, t.Name),
+				TarballUrl: fmt.Sprintf("https://gitlab.com/%s/%s/-/archive/%s/%s-%s.tar.gz", owner, repo, t.Name, repo, t.Name),
+				CommitSha:  t.Commit.Id,
+				CommitUrl:  t.Commit.WebUrl,
+			})
+		}
+	}
+	return tags, nil
+}
+
+func (g *GitLab) Changelog(owner, repo, branch string) (string, error) {
+	project := projectID(owner, repo)
+	apiUrl := fmt.Sprintf("https://gitlab.com/api/v4/projects/%s/repository/files/%s/raw?ref=%s",
+		project, url.PathEscape(g.changelogPath), url.QueryEscape(branch))
+	body, err := g.client.Get(apiUrl)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// projectID builds the URL-encoded "owner/repo" path GitLab's API expects
+// in place of a numeric project id.
+func projectID(owner, repo string) string {
+	return url.PathEscape(owner + "/" + repo)
+}
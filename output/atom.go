@@ -0,0 +1,56 @@
+package output
+
+import (
+	"io"
+	"io/ioutil"
+	"text/template"
+)
+
+// defaultAtomTemplate renders one Atom <entry> per release, with the
+// release's publish date as <updated> and its notes, flattened to plain
+// text, as <content>.
+const defaultAtomTemplate = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Changelog</title>
+  {{range .}}<entry>
+    <title>{{xmlEscape (trimV .Name)}}</title>
+    <id>{{xmlEscape .Name}}</id>
+    <updated>{{if not .PublishedAt.IsZero}}{{.PublishedAt.Format "2006-01-02T15:04:05Z07:00"}}{{else}}1970-01-01T00:00:00Z{{end}}</updated>
+    {{if .Author}}<author><name>{{xmlEscape .Author}}</name></author>{{end}}
+    <content type="text">{{if .ReleaseNotes}}{{range .ReleaseNotes.Features}}* {{xmlEscape .}}
+{{end}}{{range .ReleaseNotes.Changes}}* {{xmlEscape .}}
+{{end}}{{range .ReleaseNotes.Maintenance}}* {{xmlEscape .}}
+{{end}}{{range .ReleaseNotes.Breaking}}* {{xmlEscape .}}
+{{end}}{{range .ReleaseNotes.Fixes}}* {{xmlEscape .}}
+{{end}}{{end}}</content>
+  </entry>
+  {{end}}
+</feed>
+`
+
+// AtomRenderer renders releases as an Atom feed, one entry per version.
+type AtomRenderer struct {
+	// TemplatePath, if set, overrides defaultAtomTemplate.
+	TemplatePath string
+}
+
+func (r *AtomRenderer) Render(w io.Writer, releases []Release) error {
+	text := defaultAtomTemplate
+	if r.TemplatePath != "" {
+		b, err := ioutil.ReadFile(r.TemplatePath)
+		if err != nil {
+			return err
+		}
+		text = string(b)
+	}
+
+	tmplFuncs := map[string]interface{}{
+		"trimV":     trimV,
+		"xmlEscape": xmlEscape,
+	}
+	tmpl, err := template.New("atom").Funcs(tmplFuncs).Parse(text)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, releases)
+}
@@ -0,0 +1,27 @@
+package output
+
+import "strings"
+
+// trimV strips a leading "v" from a tag name, e.g. "v1.2.3" -> "1.2.3".
+func trimV(name string) string {
+	return strings.TrimPrefix(name, "v")
+}
+
+// funcs are the template helpers shared by MarkdownRenderer and
+// HTMLRenderer; text/template and html/template both accept this shape.
+// AtomRenderer builds its own map, with xmlEscape alongside trimV.
+var funcs = map[string]interface{}{
+	"trimV": trimV,
+}
+
+// xmlEscape escapes the handful of characters that aren't safe to put
+// directly into XML text content, for templates (like AtomRenderer's)
+// that aren't auto-escaped the way html/template is.
+func xmlEscape(s string) string {
+	replacer := strings.NewReplacer(
+		"&", "&amp;",
+		"<", "&lt;",
+		">", "&gt;",
+	)
+	return replacer.Replace(s)
+}
@@ -0,0 +1,51 @@
+package output
+
+import (
+	"html/template"
+	"io"
+	"io/ioutil"
+)
+
+const defaultHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Changelog</title></head>
+<body>
+<h1>Changelog</h1>
+{{range .}}
+<h2>{{trimV .Name}}{{if not .PublishedAt.IsZero}} &mdash; {{.PublishedAt.Format "2006-01-02"}}{{end}}</h2>
+{{if .ReleaseNotes}}
+{{if .ReleaseNotes.Features}}<h3>Added</h3><ul>{{range .ReleaseNotes.Features}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{if .ReleaseNotes.Changes}}<h3>Changed</h3><ul>{{range .ReleaseNotes.Changes}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{if .ReleaseNotes.Maintenance}}<h3>Deprecated</h3><ul>{{range .ReleaseNotes.Maintenance}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{if .ReleaseNotes.Breaking}}<h3>Removed</h3><ul>{{range .ReleaseNotes.Breaking}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{if .ReleaseNotes.Fixes}}<h3>Fixed</h3><ul>{{range .ReleaseNotes.Fixes}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{end}}
+{{end}}
+</body>
+</html>
+`
+
+// HTMLRenderer renders releases as a single static HTML page. It uses
+// html/template rather than text/template so that release notes pulled
+// from a project's own changelog can't inject markup.
+type HTMLRenderer struct {
+	// TemplatePath, if set, overrides defaultHTMLTemplate.
+	TemplatePath string
+}
+
+func (r *HTMLRenderer) Render(w io.Writer, releases []Release) error {
+	text := defaultHTMLTemplate
+	if r.TemplatePath != "" {
+		b, err := ioutil.ReadFile(r.TemplatePath)
+		if err != nil {
+			return err
+		}
+		text = string(b)
+	}
+
+	tmpl, err := template.New("html").Funcs(funcs).Parse(text)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, releases)
+}
@@ -0,0 +1,55 @@
+package output
+
+import (
+	"io"
+	"io/ioutil"
+	"text/template"
+)
+
+// defaultMarkdownTemplate renders a Keep a Changelog compliant document:
+// https://keepachangelog.com. r2c's own Fixes/Features/Maintenance/
+// Changes/Breaking buckets map onto Fixed/Added/Deprecated/Changed/Removed.
+const defaultMarkdownTemplate = `# Changelog
+{{range .}}
+## [{{trimV .Name}}]{{if not .PublishedAt.IsZero}} - {{.PublishedAt.Format "2006-01-02"}}{{end}}
+{{if .ReleaseNotes}}{{if .ReleaseNotes.Features}}
+### Added
+{{range .ReleaseNotes.Features}}- {{.}}
+{{end}}{{end}}{{if .ReleaseNotes.Changes}}
+### Changed
+{{range .ReleaseNotes.Changes}}- {{.}}
+{{end}}{{end}}{{if .ReleaseNotes.Maintenance}}
+### Deprecated
+{{range .ReleaseNotes.Maintenance}}- {{.}}
+{{end}}{{end}}{{if .ReleaseNotes.Breaking}}
+### Removed
+{{range .ReleaseNotes.Breaking}}- {{.}}
+{{end}}{{end}}{{if .ReleaseNotes.Fixes}}
+### Fixed
+{{range .ReleaseNotes.Fixes}}- {{.}}
+{{end}}{{end}}{{end}}
+{{end}}`
+
+// MarkdownRenderer renders releases as a Keep a Changelog compliant
+// Markdown document.
+type MarkdownRenderer struct {
+	// TemplatePath, if set, overrides defaultMarkdownTemplate.
+	TemplatePath string
+}
+
+func (r *MarkdownRenderer) Render(w io.Writer, releases []Release) error {
+	text := defaultMarkdownTemplate
+	if r.TemplatePath != "" {
+		b, err := ioutil.ReadFile(r.TemplatePath)
+		if err != nil {
+			return err
+		}
+		text = string(b)
+	}
+
+	tmpl, err := template.New("markdown").Funcs(funcs).Parse(text)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, releases)
+}
@@ -0,0 +1,55 @@
+// Package output renders parsed releases into the formats r2c can hand
+// off to a static site generator or a feed reader, as an alternative to
+// the raw r2c.json.
+package output
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// ReleaseNotes is the categorized notes for one release. It mirrors the
+// shape r2c parses changelogs into.
+type ReleaseNotes struct {
+	ReleaseName string
+	Version     string
+	Fixes       []string
+	Features    []string
+	Maintenance []string
+	Changes     []string
+	Breaking    []string
+}
+
+// Release is one tagged release, with whatever notes were found for it.
+type Release struct {
+	Name         string
+	ZipballUrl   string
+	TarballUrl   string
+	CommitSha    string
+	CommitUrl    string
+	ReleaseNotes *ReleaseNotes
+	PublishedAt  time.Time
+	Author       string
+}
+
+// Renderer turns a list of releases into a byte stream in some format.
+type Renderer interface {
+	Render(w io.Writer, releases []Release) error
+}
+
+// New returns the Renderer for the named format ("md", "html", or
+// "atom"). templatePath, if non-empty, overrides the renderer's built-in
+// default template.
+func New(format, templatePath string) (Renderer, error) {
+	switch format {
+	case "md", "markdown":
+		return &MarkdownRenderer{TemplatePath: templatePath}, nil
+	case "html":
+		return &HTMLRenderer{TemplatePath: templatePath}, nil
+	case "atom", "rss":
+		return &AtomRenderer{TemplatePath: templatePath}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/theanine/r2c/changelog"
+	"github.com/theanine/r2c/forge"
+)
+
+// parsedNotesCachePath maps a changelog's own content, as seen by a
+// particular Parser, to the file that would hold its already-parsed
+// ReleaseNotes, so that a changelog fetch that comes back unchanged
+// (served from forge.Client's HTTP cache on a 304) doesn't also re-run
+// the parser. The parser's type is part of the key so switching
+// --parser (or auto-detect guessing differently) can't return another
+// parser's cached result for the same changelog text.
+func parsedNotesCachePath(parser changelog.Parser, changelogText string) (string, bool) {
+	dir := forge.CacheDir()
+	if dir == "" {
+		return "", false
+	}
+	key := fmt.Sprintf("%T\x00%s", parser, changelogText)
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dir, "parsed", hex.EncodeToString(sum[:])+".json"), true
+}
+
+func loadParsedNotes(parser changelog.Parser, changelogText string) ([]changelog.ReleaseNotes, bool) {
+	path, ok := parsedNotesCachePath(parser, changelogText)
+	if !ok {
+		return nil, false
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var notes []changelog.ReleaseNotes
+	if err := json.Unmarshal(b, &notes); err != nil {
+		return nil, false
+	}
+	return notes, true
+}
+
+func saveParsedNotes(parser changelog.Parser, changelogText string, notes []changelog.ReleaseNotes) {
+	path, ok := parsedNotesCachePath(parser, changelogText)
+	if !ok {
+		return
+	}
+	b, err := json.Marshal(notes)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	_ = ioutil.WriteFile(path, b, 0644)
+}
+
+// parseChangelogCached runs parser.Parse, reusing a previous run's result
+// when this exact parser has already parsed this exact changelog text.
+func parseChangelogCached(parser changelog.Parser, input changelog.Input) []changelog.ReleaseNotes {
+	if input.Changelog == "" {
+		return parser.Parse(input)
+	}
+	if notes, ok := loadParsedNotes(parser, input.Changelog); ok {
+		return notes
+	}
+	notes := parser.Parse(input)
+	saveParsedNotes(parser, input.Changelog, notes)
+	return notes
+}
@@ -1,14 +1,21 @@
 package main
 
 import (
-	"bufio"
+	"bytes"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"log"
-	"net/http"
-	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/theanine/r2c/changelog"
+	"github.com/theanine/r2c/forge"
+	"github.com/theanine/r2c/output"
+	"github.com/theanine/r2c/semver"
 )
 
 type Commit struct {
@@ -23,6 +30,7 @@ type ReleaseNotes struct {
 	Features    []string `json:"features,omitempty"`
 	Maintenance []string `json:"maintenance,omitempty"`
 	Changes     []string `json:"changes,omitempty"`
+	Breaking    []string `json:"breaking,omitempty"`
 }
 
 type Release struct {
@@ -31,21 +39,22 @@ type Release struct {
 	TarballUrl   string        `json:"tarball_url,omitempty"`
 	Commit       Commit        `json:"commit,omitempty"`
 	ReleaseNotes *ReleaseNotes `json:"release_notes,omitempty"`
+	PublishedAt  *time.Time    `json:"published_at,omitempty"`
+	Author       string        `json:"author,omitempty"`
 }
 
 var releases []Release
 
-func wget(url string) (string, error) {
-	resp, err := http.Get(url)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
-	return string(body), nil
+// IsPrerelease reports whether r's tag name looks like a pre-release
+// version (e.g. "v1.2.3-beta.1").
+func (r Release) IsPrerelease() bool {
+	return semver.Prerelease(r.Name) != ""
+}
+
+// Compare returns -1, 0, or +1 depending on whether r's version is less
+// than, equal to, or greater than other's.
+func (r Release) Compare(other Release) int {
+	return semver.Compare(r.Name, other.Name)
 }
 
 func (r *Release) dump() {
@@ -73,172 +82,346 @@ func (r *Release) dump() {
 	for _, c := range r.ReleaseNotes.Changes {
 		fmt.Println("\t\t *", c)
 	}
+	fmt.Println("\tBreaking:")
+	for _, b := range r.ReleaseNotes.Breaking {
+		fmt.Println("\t\t *", b)
+	}
 	fmt.Println()
 }
 
-// TODO: fix these loops with maps
-func insertFix(release string, name string, line string) {
-	for i, r := range releases {
-		if r.Name == name {
-			if releases[i].ReleaseNotes == nil {
-				releases[i].ReleaseNotes = &ReleaseNotes{}
-			}
-			releases[i].ReleaseNotes.ReleaseName = release
-			releases[i].ReleaseNotes.Version = name
-			releases[i].ReleaseNotes.Fixes = append(releases[i].ReleaseNotes.Fixes, line)
-		}
+// toRelease converts a forge.Tag, which knows nothing about changelogs,
+// into the Release shape r2c has always emitted.
+func toRelease(t forge.Tag) Release {
+	return Release{
+		Name:       t.Name,
+		ZipballUrl: t.ZipballUrl,
+		TarballUrl: t.TarballUrl,
+		Commit: Commit{
+			Sha: t.CommitSha,
+			Url: t.CommitUrl,
+		},
 	}
 }
 
-func insertFeature(release string, name string, line string) {
-	for i, r := range releases {
-		if r.Name == name {
-			if releases[i].ReleaseNotes == nil {
-				releases[i].ReleaseNotes = &ReleaseNotes{}
+// toReleaseNotes converts a changelog.ReleaseNotes, which knows nothing
+// about the output format, into the ReleaseNotes shape r2c emits.
+func toReleaseNotes(n changelog.ReleaseNotes) *ReleaseNotes {
+	return &ReleaseNotes{
+		ReleaseName: n.ReleaseName,
+		Version:     n.Version,
+		Fixes:       n.Fixes,
+		Features:    n.Features,
+		Maintenance: n.Maintenance,
+		Changes:     n.Changes,
+		Breaking:    n.Breaking,
+	}
+}
+
+// applyReleaseNotes matches each parsed ReleaseNotes to the Release with
+// the same version and attaches it.
+func applyReleaseNotes(notes []changelog.ReleaseNotes) {
+	for _, n := range notes {
+		for i, r := range releases {
+			if r.Name == n.Version {
+				releases[i].ReleaseNotes = toReleaseNotes(n)
 			}
-			releases[i].ReleaseNotes.ReleaseName = release
-			releases[i].ReleaseNotes.Version = name
-			releases[i].ReleaseNotes.Features = append(releases[i].ReleaseNotes.Features, line)
 		}
 	}
 }
 
-func insertMaintenance(release string, name string, line string) {
-	for i, r := range releases {
-		if r.Name == name {
-			if releases[i].ReleaseNotes == nil {
-				releases[i].ReleaseNotes = &ReleaseNotes{}
+// toOutputReleases converts Releases into the shape the output package's
+// renderers expect.
+func toOutputReleases(rs []Release) []output.Release {
+	out := make([]output.Release, len(rs))
+	for i, r := range rs {
+		or := output.Release{
+			Name:       r.Name,
+			ZipballUrl: r.ZipballUrl,
+			TarballUrl: r.TarballUrl,
+			CommitSha:  r.Commit.Sha,
+			CommitUrl:  r.Commit.Url,
+			Author:     r.Author,
+		}
+		if r.PublishedAt != nil {
+			or.PublishedAt = *r.PublishedAt
+		}
+		if r.ReleaseNotes != nil {
+			or.ReleaseNotes = &output.ReleaseNotes{
+				ReleaseName: r.ReleaseNotes.ReleaseName,
+				Version:     r.ReleaseNotes.Version,
+				Fixes:       r.ReleaseNotes.Fixes,
+				Features:    r.ReleaseNotes.Features,
+				Maintenance: r.ReleaseNotes.Maintenance,
+				Changes:     r.ReleaseNotes.Changes,
+				Breaking:    r.ReleaseNotes.Breaking,
 			}
-			releases[i].ReleaseNotes.ReleaseName = release
-			releases[i].ReleaseNotes.Version = name
-			releases[i].ReleaseNotes.Maintenance = append(releases[i].ReleaseNotes.Maintenance, line)
 		}
+		out[i] = or
 	}
+	return out
 }
 
-func insertChange(release string, name string, line string) {
-	for i, r := range releases {
-		if r.Name == name {
+// mergeReleaseMeta layers a forge's own releases API onto releases parsed
+// from tags: PublishedAt and Author always come from here, and the
+// release body becomes the release notes for any version the changelog
+// didn't mention at all.
+func mergeReleaseMeta(metas []forge.ReleaseMeta) {
+	for _, m := range metas {
+		publishedAt := m.PublishedAt
+		for i, r := range releases {
+			if r.Name != m.TagName {
+				continue
+			}
+			if !publishedAt.IsZero() {
+				releases[i].PublishedAt = &publishedAt
+			}
+			releases[i].Author = m.Author
 			if releases[i].ReleaseNotes == nil {
-				releases[i].ReleaseNotes = &ReleaseNotes{}
+				if changes := splitReleaseBody(m.Body); changes != nil {
+					releases[i].ReleaseNotes = &ReleaseNotes{
+						Version: m.TagName,
+						Changes: changes,
+					}
+				}
 			}
-			releases[i].ReleaseNotes.ReleaseName = release
-			releases[i].ReleaseNotes.Version = name
-			releases[i].ReleaseNotes.Changes = append(releases[i].ReleaseNotes.Changes, line)
 		}
 	}
 }
 
-func parseChangelog(changelog string) {
-	validVersion := regexp.MustCompile(`[0-9]+\.[0-9]+\.[0-9]+`)
+// splitReleaseBody turns a GitHub release body into changelog-style lines,
+// stripping the "* " / "- " bullets it's usually written with.
+func splitReleaseBody(body string) []string {
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		line = strings.TrimPrefix(line, "* ")
+		line = strings.TrimPrefix(line, "- ")
+		lines = append(lines, line)
+	}
+	return lines
+}
 
-	release := ""
-	name := ""
-	comment := ""
-	fixes := false
-	features := false
-	maintenance := false
+// sortAndFilterReleases sorts releases newest-first by semver (forges
+// don't guarantee their tags/releases endpoints return any particular
+// order), then narrows the result down to what the user asked for via
+// --since, --until, --pre-releases, and --latest, in that order.
+func sortAndFilterReleases(releases []Release, since, until string, includePrereleases bool, latest int) []Release {
+	sorted := make([]Release, len(releases))
+	copy(sorted, releases)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Compare(sorted[j]) > 0
+	})
 
-	scanner := bufio.NewScanner(strings.NewReader(changelog))
-	for scanner.Scan() {
-		line := strings.TrimRight(scanner.Text(), " \t\n\v\f\r")
-		if len(line) == 0 {
+	var kept []Release
+	for _, r := range sorted {
+		if !includePrereleases && r.IsPrerelease() {
 			continue
 		}
-		// buffer comments first to handle multilined comments
-		if len(line) >= 1 && line[0] != '#' && line[0] != '*' {
-			comment += "\n" + strings.TrimSpace(line)
+		if since != "" && semver.IsValid(r.Name) && semver.Compare(r.Name, since) < 0 {
 			continue
 		}
-		// handle buffered comments
-		if comment != "" {
-			if fixes {
-				insertFix(release, name, comment)
-			} else if features {
-				insertFeature(release, name, comment)
-			} else if maintenance {
-				insertMaintenance(release, name, comment)
-			} else {
-				insertChange(release, name, comment)
-			}
+		if until != "" && semver.IsValid(r.Name) && semver.Compare(r.Name, until) > 0 {
+			continue
 		}
-		// Releases
-		if len(line) >= 3 && line[:3] == "## " {
-			release = line[3:]
-			// NOTE: doesn't handle edge case "jest 22.0.2 && 22.0.3"
-			name = "v" + string(validVersion.Find([]byte(release)))
-			comment = ""
-			fixes = false
-			features = false
-			maintenance = false
-		}
-		if !validVersion.MatchString(release) {
+		kept = append(kept, r)
+	}
+	if latest > 0 && latest < len(kept) {
+		kept = kept[:latest]
+	}
+	return kept
+}
+
+// commitsByVersion fetches, for each tag, the commit messages between it
+// and the next-oldest tag, keyed by the newer tag's name, using up to
+// concurrency requests at once. It's only used by the Conventional
+// Commits parser, and only when the forge supports listing commits
+// between refs. names must be newest-first.
+func commitsByVersion(f forge.Forger, owner, repo string, names []string, concurrency int) map[string][]string {
+	cl, ok := f.(forge.CommitLister)
+	if !ok {
+		return nil
+	}
+
+	type job struct{ base, head string }
+	var jobs []job
+	for i, name := range names {
+		if i+1 >= len(names) {
 			continue
 		}
-		// Fixes / Features / Maintenance
-		if len(line) >= 4 && line[:4] == "### " {
-			if strings.Contains(line, "Fixes") {
-				comment = ""
-				fixes = true
-				features = false
-				maintenance = false
-			} else if strings.Contains(line, "Features") {
-				comment = ""
-				fixes = false
-				features = true
-				maintenance = false
-			} else if strings.Contains(line, "Chore & Maintenance") {
-				comment = ""
-				fixes = false
-				features = false
-				maintenance = true
+		jobs = append(jobs, job{base: names[i+1], head: name})
+	}
+
+	out := make(map[string][]string, len(jobs))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for _, j := range jobs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(j job) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			messages, err := cl.CommitMessages(owner, repo, j.base, j.head)
+			if err != nil {
+				log.Fatalln(err)
 			}
-		}
-		// Comments
-		if len(line) >= 2 && line[:2] == "* " {
-			comment = line[2:]
-		}
+			mu.Lock()
+			out[j.head] = messages
+			mu.Unlock()
+		}(j)
 	}
+	wg.Wait()
+	return out
 }
 
-const changelogURL = "https://raw.githubusercontent.com/facebook/jest/master/CHANGELOG.md"
-const tagsURL = "https://api.github.com/repos/facebook/jest/tags"
+// fetchTagsAndChangelog runs the tags, changelog, and (when supported)
+// releases-API requests concurrently, at most concurrency at once, since
+// none of them depend on each other. The changelog fetch is skipped
+// entirely when needChangelog is false, since the Conventional Commits
+// parser derives its notes from commit messages and doesn't need a
+// changelog file to exist at all.
+func fetchTagsAndChangelog(f forge.Forger, owner, repo, branch string, needChangelog bool, concurrency int) (tags []forge.Tag, changelogText string, metas []forge.ReleaseMeta, err error) {
+	var tagsErr, changelogErr, metasErr error
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		sem <- struct{}{}
+		defer func() { <-sem }()
+		tags, tagsErr = f.Tags(owner, repo)
+	}()
+
+	if needChangelog {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			changelogText, changelogErr = f.Changelog(owner, repo, branch)
+		}()
+	}
+
+	if rf, ok := f.(forge.ReleaseFetcher); ok {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			metas, metasErr = rf.Releases(owner, repo)
+		}()
+	}
+
+	wg.Wait()
+	for _, e := range []error{tagsErr, changelogErr, metasErr} {
+		if e != nil {
+			return nil, "", nil, e
+		}
+	}
+	return tags, changelogText, metas, nil
+}
 
 func main() {
-	// Get tags
-	tags, err := wget(tagsURL)
-	if err != nil {
-		log.Fatalln(err)
+	forgeName := flag.String("forge", "github", "forge backend to use: github, gitlab, or gitea")
+	branch := flag.String("branch", "master", "branch to read the changelog from")
+	changelogPath := flag.String("changelog-path", "CHANGELOG.md", "path to the changelog file in the repo")
+	parserName := flag.String("parser", "auto", "changelog parser to use: auto, jest, keepachangelog, or conventional-commits")
+	token := flag.String("token", "", "auth token for the forge API")
+	outputPath := flag.String("output", "r2c.json", "file to write the rendered releases to")
+	format := flag.String("format", "json", "output format: json, md, html, or atom")
+	templatePath := flag.String("template", "", "custom text/template file for --format md/html/atom")
+	since := flag.String("since", "", "only include releases at or after this version")
+	until := flag.String("until", "", "only include releases at or before this version")
+	latest := flag.Int("latest", 0, "only include the N most recent releases (0 means all)")
+	prereleases := flag.Bool("pre-releases", true, "include pre-release versions")
+	concurrency := flag.Int("concurrency", 4, "max number of forge requests to run at once")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		log.Fatalln("usage: r2c [flags] <owner>/<repo>")
+	}
+
+	ownerRepo := strings.SplitN(flag.Arg(0), "/", 2)
+	if len(ownerRepo) != 2 {
+		log.Fatalln("repo must be in <owner>/<repo> form")
 	}
+	owner, repo := ownerRepo[0], ownerRepo[1]
 
-	// Unmarshal tags into data structure
-	err = json.Unmarshal([]byte(tags), &releases)
+	f, err := forge.New(*forgeName, forge.NewClient(*token), *changelogPath)
 	if err != nil {
 		log.Fatalln(err)
 	}
 
-	// Get changelog
-	changelog, err := wget(changelogURL)
+	// Fetch tags, the changelog, and (if supported) the releases API all
+	// at once instead of one after another. Skip the changelog fetch when
+	// the user explicitly asked for Conventional Commits, since that
+	// parser doesn't need one and many repos that use it have none.
+	needChangelog := *parserName != "conventional-commits"
+	tags, changelogText, metas, err := fetchTagsAndChangelog(f, owner, repo, *branch, needChangelog, *concurrency)
 	if err != nil {
 		log.Fatalln(err)
 	}
+	unfiltered := make([]Release, len(tags))
+	for i, t := range tags {
+		unfiltered[i] = toRelease(t)
+	}
+	releases = sortAndFilterReleases(unfiltered, *since, *until, *prereleases, *latest)
 
-	// Parse changelog
-	parseChangelog(changelog)
+	// Pick and run a changelog parser
+	var parser changelog.Parser
+	if *parserName == "auto" {
+		parser = changelog.Detect(changelogText)
+	} else {
+		parser, err = changelog.New(*parserName)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	input := changelog.Input{Changelog: changelogText}
+	if _, ok := parser.(*changelog.ConventionalCommitsParser); ok {
+		names := make([]string, len(releases))
+		for i, r := range releases {
+			names[i] = r.Name
+		}
+		input.CommitsByVersion = commitsByVersion(f, owner, repo, names, *concurrency)
+	}
+	applyReleaseNotes(parseChangelogCached(parser, input))
+
+	// Fall back to the forge's own releases API for versions the
+	// changelog doesn't mention, and pick up publish date/author while
+	// we're there.
+	mergeReleaseMeta(metas)
 
 	// DEBUG: Dump releases
 	// for _, r := range releases {
 	// 	r.dump()
 	// }
 
-	// Marshal tags into bytes
-	b, err := json.Marshal(releases)
-	if err != nil {
-		log.Fatalln(err)
+	var b []byte
+	if *format == "json" {
+		b, err = json.Marshal(releases)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	} else {
+		renderer, err := output.New(*format, *templatePath)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		var buf bytes.Buffer
+		if err := renderer.Render(&buf, toOutputReleases(releases)); err != nil {
+			log.Fatalln(err)
+		}
+		b = buf.Bytes()
 	}
 
 	// Write to file
-	err = ioutil.WriteFile("r2c.json", b, 0644)
+	err = ioutil.WriteFile(*outputPath, b, 0644)
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -0,0 +1,160 @@
+// Package semver provides just enough semantic-version parsing and
+// comparison for r2c to filter and sort releases. It mirrors the parts of
+// golang.org/x/mod/semver that r2c needs, without the module dependency.
+package semver
+
+import (
+	"strconv"
+	"strings"
+)
+
+// parsed is a decomposed semantic version.
+type parsed struct {
+	major, minor, patch int
+	prerelease          string
+	ok                  bool
+}
+
+// parse decomposes a version string, tolerating a leading "v" and
+// ignoring build metadata ("+...").
+func parse(v string) parsed {
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexByte(v, '+'); i >= 0 {
+		v = v[:i]
+	}
+
+	prerelease := ""
+	if i := strings.IndexByte(v, '-'); i >= 0 {
+		prerelease = v[i+1:]
+		v = v[:i]
+	}
+
+	parts := strings.SplitN(v, ".", 3)
+	if len(parts) != 3 {
+		return parsed{}
+	}
+	major, err1 := strconv.Atoi(parts[0])
+	minor, err2 := strconv.Atoi(parts[1])
+	patch, err3 := strconv.Atoi(parts[2])
+	if err1 != nil || err2 != nil || err3 != nil {
+		return parsed{}
+	}
+
+	return parsed{major: major, minor: minor, patch: patch, prerelease: prerelease, ok: true}
+}
+
+// IsValid reports whether v is a well-formed (optionally "v"-prefixed)
+// major.minor.patch version.
+func IsValid(v string) bool {
+	return parse(v).ok
+}
+
+// Canonical returns v with a leading "v", e.g. "1.2.3" -> "v1.2.3".
+func Canonical(v string) string {
+	if strings.HasPrefix(v, "v") {
+		return v
+	}
+	return "v" + v
+}
+
+// Prerelease returns the "-beta.1" suffix of v, including the leading
+// hyphen, or "" if v has none or isn't a valid version.
+func Prerelease(v string) string {
+	p := parse(v)
+	if !p.ok || p.prerelease == "" {
+		return ""
+	}
+	return "-" + p.prerelease
+}
+
+// Compare returns -1, 0, or +1 depending on whether v1 is less than,
+// equal to, or greater than v2. Invalid versions sort before valid ones.
+func Compare(v1, v2 string) int {
+	p1, p2 := parse(v1), parse(v2)
+	if !p1.ok || !p2.ok {
+		return boolCompare(p1.ok, p2.ok)
+	}
+	if c := intCompare(p1.major, p2.major); c != 0 {
+		return c
+	}
+	if c := intCompare(p1.minor, p2.minor); c != 0 {
+		return c
+	}
+	if c := intCompare(p1.patch, p2.patch); c != 0 {
+		return c
+	}
+	return prereleaseCompare(p1.prerelease, p2.prerelease)
+}
+
+func intCompare(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func boolCompare(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case b:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// prereleaseCompare treats "no prerelease" as higher than any prerelease,
+// matching semver precedence (1.0.0 > 1.0.0-rc.1). Otherwise it compares
+// the prerelease dot by dot per semver.org #11.4: numeric identifiers
+// compare numerically, non-numeric ones compare as strings, and a
+// shorter set of identifiers sorts before a longer one that's otherwise
+// equal (e.g. "rc" < "rc.1").
+func prereleaseCompare(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	aIDs, bIDs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aIDs) && i < len(bIDs); i++ {
+		if c := identifierCompare(aIDs[i], bIDs[i]); c != 0 {
+			return c
+		}
+	}
+	return intCompare(len(aIDs), len(bIDs))
+}
+
+// identifierCompare compares a single dot-separated prerelease identifier.
+// Identifiers that are both entirely numeric compare as integers; a
+// numeric identifier sorts before a non-numeric one, as semver says; two
+// non-numeric identifiers fall back to a plain string compare.
+func identifierCompare(a, b string) int {
+	an, aErr := strconv.Atoi(a)
+	bn, bErr := strconv.Atoi(b)
+	if aErr == nil && bErr == nil {
+		return intCompare(an, bn)
+	}
+	if aErr == nil {
+		return -1
+	}
+	if bErr == nil {
+		return 1
+	}
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
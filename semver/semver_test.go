@@ -0,0 +1,75 @@
+package semver
+
+import "testing"
+
+func TestIsValid(t *testing.T) {
+	cases := map[string]bool{
+		"v1.2.3":      true,
+		"1.2.3":       true,
+		"v1.2.3-rc.1": true,
+		"v1.2":        false,
+		"latest":      false,
+		"":            false,
+	}
+	for v, want := range cases {
+		if got := IsValid(v); got != want {
+			t.Errorf("IsValid(%q) = %v, want %v", v, got, want)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	cases := map[string]string{
+		"1.2.3":  "v1.2.3",
+		"v1.2.3": "v1.2.3",
+	}
+	for v, want := range cases {
+		if got := Canonical(v); got != want {
+			t.Errorf("Canonical(%q) = %q, want %q", v, got, want)
+		}
+	}
+}
+
+func TestPrerelease(t *testing.T) {
+	cases := map[string]string{
+		"v1.2.3":        "",
+		"v1.2.3-beta.1": "-beta.1",
+		"not-a-version": "",
+	}
+	for v, want := range cases {
+		if got := Prerelease(v); got != want {
+			t.Errorf("Prerelease(%q) = %q, want %q", v, got, want)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.0.0", "v1.0.0", 0},
+		{"v1.0.0", "v1.0.1", -1},
+		{"v1.2.0", "v1.1.9", 1},
+		{"v2.0.0", "v1.9.9", 1},
+		// a release outranks any of its own pre-releases
+		{"v1.0.0", "v1.0.0-rc.1", 1},
+		{"v1.0.0-rc.1", "v1.0.0", -1},
+		// invalid versions sort before valid ones
+		{"not-a-version", "v1.0.0", -1},
+		{"v1.0.0", "not-a-version", 1},
+		// numeric prerelease identifiers compare numerically, not as strings
+		{"v1.0.0-rc.2", "v1.0.0-rc.10", -1},
+		{"v1.0.0-rc.10", "v1.0.0-rc.2", 1},
+		{"v1.0.0-beta.9", "v1.0.0-beta.10", -1},
+		// non-numeric identifiers still compare as strings
+		{"v1.0.0-alpha", "v1.0.0-beta", -1},
+		// a shorter identifier list sorts before a longer, otherwise-equal one
+		{"v1.0.0-rc", "v1.0.0-rc.1", -1},
+	}
+	for _, c := range cases {
+		if got := Compare(c.a, c.b); got != c.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}